@@ -0,0 +1,24 @@
+package logger
+
+import "context"
+
+// contextKey is an unexported type to avoid collisions with context keys
+// set by other packages.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying l, retrievable with
+// FromContext. This lets middleware attach a request-scoped logger (e.g.
+// enriched with a request id via With) for downstream handlers to use.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the Logger stored in ctx by NewContext, or a no-op
+// logger if ctx carries none.
+func FromContext(ctx context.Context) Logger {
+	l, ok := ctx.Value(contextKey{}).(Logger)
+	if !ok {
+		return NewNoop()
+	}
+	return l
+}
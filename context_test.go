@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestContextRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	ctx := NewContext(context.Background(), l.With("request_id", "xyz"))
+	FromContext(ctx).Info("request handled")
+
+	out := buf.String()
+	for _, want := range []string{"request_id", "xyz", "request handled"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got %q", want, out)
+		}
+	}
+}
+
+func TestFromContextWithoutLogger(t *testing.T) {
+	l := FromContext(context.Background())
+
+	// Should not panic and should be silent.
+	l.Info("test")
+}
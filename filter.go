@@ -0,0 +1,54 @@
+package logger
+
+// filter wraps a Logger with its own level gate, independent of the
+// underlying implementation's configured level (mirroring tendermint's
+// log/filter.go). This lets callers compose filters over any Logger —
+// including NoopLogger or a user-supplied implementation — without
+// constructing a whole new zerolog pipeline.
+type filter struct {
+	next  Logger
+	allow Level
+}
+
+// NewFilter wraps next so that only calls at or above allow reach it.
+// Error always passes through unless allow is LevelDisabled, matching the
+// behavior of the static Level passed to New.
+func NewFilter(next Logger, allow Level) Logger {
+	return &filter{next: next, allow: allow}
+}
+
+func (f *filter) Info(msg string, keyvals ...interface{}) {
+	if f.allow <= LevelInfo {
+		f.next.Info(msg, keyvals...)
+	}
+}
+
+func (f *filter) Event(msg string, keyvals ...interface{}) {
+	if f.allow <= LevelEvent {
+		f.next.Event(msg, keyvals...)
+	}
+}
+
+func (f *filter) Debug(msg string, keyvals ...interface{}) {
+	if f.allow <= LevelDebug {
+		f.next.Debug(msg, keyvals...)
+	}
+}
+
+func (f *filter) Error(err error, msg string, keyvals ...interface{}) {
+	if f.allow <= LevelEvent {
+		f.next.Error(err, msg, keyvals...)
+	}
+}
+
+func (f *filter) Scope(name string) Logger {
+	return &filter{next: f.next.Scope(name), allow: f.allow}
+}
+
+func (f *filter) With(keyvals ...interface{}) Logger {
+	return &filter{next: f.next.With(keyvals...), allow: f.allow}
+}
+
+func (f *filter) Sampled() Logger {
+	return &filter{next: f.next.Sampled(), allow: f.allow}
+}
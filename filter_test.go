@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewFilter(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelDebug, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	filtered := NewFilter(l, LevelEvent)
+	filtered.Debug("should-not-appear")
+	filtered.Info("should-not-appear-either")
+	filtered.Event("should-appear")
+	filtered.Error(errors.New("err"), "should-appear-too")
+
+	out := buf.String()
+	if strings.Contains(out, "should-not-appear") || strings.Contains(out, "should-not-appear-either") {
+		t.Errorf("expected info/debug to be filtered out, got %q", out)
+	}
+	for _, want := range []string{"should-appear", "should-appear-too"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got %q", want, out)
+		}
+	}
+}
+
+func TestNewFilterDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelDebug, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	filtered := NewFilter(l, LevelDisabled)
+	filtered.Error(errors.New("err"), "silent")
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output when allow is LevelDisabled, got %q", buf.String())
+	}
+}
+
+func TestNewFilterOnNoop(t *testing.T) {
+	filtered := NewFilter(NewNoop(), LevelInfo)
+
+	// Should not panic.
+	filtered.Info("test")
+	filtered.Scope("sub").With("k", "v").Debug("test")
+}
@@ -15,14 +15,27 @@
 // With file logging:
 //
 //	l, err := logger.New(logger.LevelDebug, logger.WithFile("logs/app.log"))
+//
+// Each sink can be rendered in its own format (colored console, JSON, or
+// logfmt), which is useful for e.g. keeping colored console output for
+// humans while writing machine-parseable JSON to a file:
+//
+//	l, err := logger.New(logger.LevelInfo,
+//	    logger.WithSink(os.Stdout, logger.FormatConsole),
+//	    logger.WithSink(file, logger.FormatJSON),
+//	)
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 )
@@ -47,6 +60,41 @@ var levelMap = map[Level]zerolog.Level{
 	LevelDisabled: zerolog.Disabled,
 }
 
+// zerologLevel converts level to its zerolog.Level equivalent, falling back
+// to zerolog.Disabled for unrecognized values.
+func zerologLevel(level Level) zerolog.Level {
+	zlevel, ok := levelMap[level]
+	if !ok {
+		return zerolog.Disabled
+	}
+	return zlevel
+}
+
+// LevelVar holds a Level that can be read and changed atomically, letting
+// the minimum log level of a Logger built with WithLevelVar be adjusted at
+// runtime (e.g. from a SIGUSR1 handler or a debug HTTP endpoint) without
+// rebuilding the logger.
+type LevelVar struct {
+	v atomic.Int32
+}
+
+// NewLevelVar returns a LevelVar initialized to level.
+func NewLevelVar(level Level) *LevelVar {
+	lv := &LevelVar{}
+	lv.Set(level)
+	return lv
+}
+
+// Level returns the current value of lv.
+func (lv *LevelVar) Level() Level {
+	return Level(lv.v.Load())
+}
+
+// Set updates lv to level.
+func (lv *LevelVar) Set(level Level) {
+	lv.v.Store(int32(level))
+}
+
 // ParseLevel converts a string to a Level. It is case-insensitive.
 // Unrecognized strings return LevelDisabled.
 func ParseLevel(s string) Level {
@@ -63,27 +111,135 @@ func ParseLevel(s string) Level {
 }
 
 // Logger is the interface for structured logging with scoping support.
+//
+// Info, Event, Debug, and Error accept an optional list of alternating
+// key/value pairs that are attached to the log entry as structured fields,
+// e.g. l.Info("server started", "port", 8080, "tls", true). An odd number
+// of keyvals results in the trailing value being logged under the
+// badKeyMarker field.
+//
+// With returns a child logger carrying its own keyvals as persistent
+// fields, attached to every subsequent call. Scope accumulates into a
+// single dotted "scope" field (e.g. "auth.login"), so nested scopes build
+// on one another rather than one replacing the last.
 type Logger interface {
-	Info(string)
-	Event(string)
-	Debug(string, string)
-	Error(error, string)
+	Info(string, ...interface{})
+	Event(string, ...interface{})
+	Debug(string, ...interface{})
+	Error(error, string, ...interface{})
 	Scope(string) Logger
+	With(...interface{}) Logger
+	// Sampled returns a Logger that applies a sensible default sampler
+	// (zerolog.Sometimes) to this call site, without requiring the root
+	// logger to be reconfigured via WithSampling.
+	Sampled() Logger
+}
+
+// badKeyMarker is the field name used for a keyvals value that has no
+// matching key, mirroring the convention used by the standard library's
+// log/slog package.
+const badKeyMarker = "!BADKEY"
+
+// fieldsFromKeyvals turns an alternating key/value slice into a map
+// suitable for zerolog's Fields(). Non-string keys are stringified, and a
+// trailing value with no key is recorded under badKeyMarker.
+func fieldsFromKeyvals(keyvals ...interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, (len(keyvals)+1)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", keyvals[i])
+		}
+		fields[key] = keyvals[i+1]
+	}
+	if len(keyvals)%2 == 1 {
+		fields[badKeyMarker] = keyvals[len(keyvals)-1]
+	}
+	return fields
+}
+
+// Format controls how a sink renders log entries.
+type Format int
+
+const (
+	FormatConsole Format = iota // FormatConsole writes colored, human-readable output.
+	FormatJSON                  // FormatJSON writes newline-delimited JSON.
+	FormatLogfmt                // FormatLogfmt writes space-separated key=value pairs.
+)
+
+// ParseFormat converts a string to a Format. It is case-insensitive.
+// Unrecognized strings return FormatConsole.
+func ParseFormat(s string) Format {
+	switch strings.ToLower(s) {
+	case "json":
+		return FormatJSON
+	case "logfmt":
+		return FormatLogfmt
+	default:
+		return FormatConsole
+	}
+}
+
+// sink pairs an output destination with the format used to render it.
+type sink struct {
+	w      io.Writer
+	format Format
 }
 
 // Option configures a Logger created by New.
 type Option func(*options)
 
 type options struct {
-	filePath string
-	writer   io.Writer
+	sinks    []sink
+	format   Format
+	levelVar *LevelVar
+	sampler  zerolog.Sampler
+	err      error
+}
+
+// WithLevelVar makes the logger consult lv for its minimum level on every
+// call instead of the static level passed to New, so the level can be
+// changed at runtime via lv.Set.
+func WithLevelVar(lv *LevelVar) Option {
+	return func(o *options) {
+		o.levelVar = lv
+	}
+}
+
+// WithFormat sets the format of the default stdout sink used when no
+// WithWriter, WithFile, or WithSink option is given. It has no effect once
+// any of those are used.
+func WithFormat(format Format) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithSink adds an additional output destination rendered in the given
+// format, e.g. colored console to stdout and JSON to a file. Sinks combine;
+// they don't replace each other.
+func WithSink(w io.Writer, format Format) Option {
+	return func(o *options) {
+		o.sinks = append(o.sinks, sink{w: w, format: format})
+	}
 }
 
-// WithFile enables additional file logging at the given path.
+// WithFile adds a JSON file sink at the given path. Like WithWriter and
+// WithSink, it replaces the default stdout sink rather than adding to it.
 // Parent directories are created automatically if they don't exist.
 func WithFile(path string) Option {
 	return func(o *options) {
-		o.filePath = path
+		dir := filepath.Dir(path)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			o.err = fmt.Errorf("logger: create log directory: %w", err)
+			return
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			o.err = fmt.Errorf("logger: open log file: %w", err)
+			return
+		}
+		o.sinks = append(o.sinks, sink{w: f, format: FormatJSON})
 	}
 }
 
@@ -91,47 +247,57 @@ func WithFile(path string) Option {
 // This is useful for testing or redirecting output.
 func WithWriter(w io.Writer) Option {
 	return func(o *options) {
-		o.writer = w
+		o.sinks = append(o.sinks, sink{w: w, format: FormatConsole})
 	}
 }
 
 // New creates a Logger at the given level. By default it writes colored output
-// to stdout. Use WithFile or WithWriter to customize output destinations.
+// to stdout. Use WithFile, WithWriter, or WithSink to customize output
+// destinations and formats.
 func New(level Level, opts ...Option) (Logger, error) {
 	cfg := &options{
-		writer: os.Stdout,
+		format: FormatConsole,
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
 
-	writers := []io.Writer{newConsoleWriter(cfg.writer)}
-
-	if cfg.filePath != "" {
-		dir := filepath.Dir(cfg.filePath)
-		if err := os.MkdirAll(dir, 0750); err != nil {
-			return nil, fmt.Errorf("logger: create log directory: %w", err)
-		}
-		f, err := os.OpenFile(cfg.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err != nil {
-			return nil, fmt.Errorf("logger: open log file: %w", err)
-		}
-		writers = append(writers, f)
+	if len(cfg.sinks) == 0 {
+		cfg.sinks = []sink{{w: os.Stdout, format: cfg.format}}
 	}
 
-	zlevel, ok := levelMap[level]
-	if !ok {
-		zlevel = zerolog.Disabled
+	writers := make([]io.Writer, 0, len(cfg.sinks))
+	for _, s := range cfg.sinks {
+		writers = append(writers, newFormatWriter(s))
 	}
 
 	zl := zerolog.
 		New(zerolog.MultiLevelWriter(writers...)).
-		Level(zlevel).
+		Level(zerologLevel(level)).
 		With().
 		Timestamp().
 		Logger()
 
-	return &logger{zl, "main"}, nil
+	if cfg.sampler != nil {
+		zl = zl.Sample(cfg.sampler)
+	}
+
+	return &logger{zl: zl, scope: "main", levelVar: cfg.levelVar}, nil
+}
+
+// newFormatWriter wraps s.w so zerolog's JSON output is rendered in s.format.
+func newFormatWriter(s sink) io.Writer {
+	switch s.format {
+	case FormatJSON:
+		return s.w
+	case FormatLogfmt:
+		return newLogfmtWriter(s.w)
+	default:
+		return newConsoleWriter(s.w)
+	}
 }
 
 func newConsoleWriter(w io.Writer) zerolog.ConsoleWriter {
@@ -168,9 +334,64 @@ func newConsoleWriter(w io.Writer) zerolog.ConsoleWriter {
 	}
 }
 
+// logfmtWriter re-encodes the JSON lines zerolog produces as logfmt-style
+// "key=value" pairs sorted by key.
+type logfmtWriter struct {
+	w io.Writer
+}
+
+func newLogfmtWriter(w io.Writer) io.Writer {
+	return &logfmtWriter{w: w}
+}
+
+func (lw *logfmtWriter) Write(p []byte) (int, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(p, &fields); err != nil {
+		return lw.w.Write(p)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&buf, "%s=%s", k, logfmtValue(fields[k]))
+	}
+	buf.WriteByte('\n')
+
+	if _, err := lw.w.Write([]byte(buf.String())); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// logfmtValue stringifies v, quoting it if it contains characters that would
+// otherwise make the key=value pair ambiguous.
+func logfmtValue(v interface{}) string {
+	s := fmt.Sprintf("%v", v)
+	if strings.ContainsAny(s, " \t\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
 type logger struct {
-	zl    zerolog.Logger
+	zl zerolog.Logger
+	// scope is the dotted concatenation of every Scope call in this
+	// logger's lineage (e.g. "main.auth.login"), applied as a single field
+	// at log time rather than accumulated in zl's context, since zerolog
+	// contexts append rather than replace same-named fields.
 	scope string
+	// levelVar, when set, overrides zl's own level on every call so the
+	// minimum level can change at runtime. Nil means zl's static level
+	// (set at New time) governs filtering.
+	levelVar *LevelVar
 }
 
 func init() {
@@ -182,23 +403,61 @@ func init() {
 	}
 }
 
-func (l *logger) Info(msg string) {
-	l.zl.Info().Msgf("[%s] %s", l.scope, msg)
+// zlogger returns the zerolog.Logger to log through, applying l.levelVar's
+// current value if one is configured.
+func (l *logger) zlogger() zerolog.Logger {
+	if l.levelVar == nil {
+		return l.zl
+	}
+	return l.zl.Level(zerologLevel(l.levelVar.Level()))
+}
+
+// log emits ev with msg, attaching l.scope and any keyvals as structured
+// fields. Fields set via With are already part of l.zl's persistent
+// context and need no extra work here.
+func (l *logger) log(ev *zerolog.Event, msg string, keyvals ...interface{}) {
+	if len(keyvals) > 0 {
+		ev = ev.Fields(fieldsFromKeyvals(keyvals...))
+	}
+	ev.Str("scope", l.scope).Msg(msg)
+}
+
+func (l *logger) Info(msg string, keyvals ...interface{}) {
+	zl := l.zlogger()
+	l.log(zl.Info(), msg, keyvals...)
 }
 
-func (l *logger) Event(msg string) {
-	l.zl.WithLevel(eventLevel).Msgf("-%s (%s)", msg, l.scope)
+func (l *logger) Event(msg string, keyvals ...interface{}) {
+	zl := l.zlogger()
+	l.log(zl.WithLevel(eventLevel), msg, keyvals...)
 }
 
-func (l *logger) Debug(key, value string) {
-	l.zl.Debug().Msgf(" %s: %s (%s)", key, value, l.scope)
+func (l *logger) Debug(msg string, keyvals ...interface{}) {
+	zl := l.zlogger()
+	l.log(zl.Debug(), msg, keyvals...)
 }
 
-func (l *logger) Error(err error, msg string) {
-	l.zl.Error().Err(err).Msgf("[%s] %s", l.scope, msg)
+func (l *logger) Error(err error, msg string, keyvals ...interface{}) {
+	zl := l.zlogger()
+	l.log(zl.Error().Err(err), msg, keyvals...)
 }
 
 func (l *logger) Scope(name string) Logger {
-	return &logger{l.zl.With().Logger(), name}
+	scope := name
+	if l.scope != "" {
+		scope = l.scope + "." + name
+	}
+	return &logger{zl: l.zl, scope: scope, levelVar: l.levelVar}
 }
 
+func (l *logger) With(keyvals ...interface{}) Logger {
+	ctx := l.zl.With()
+	if len(keyvals) > 0 {
+		ctx = ctx.Fields(fieldsFromKeyvals(keyvals...))
+	}
+	return &logger{zl: ctx.Logger(), scope: l.scope, levelVar: l.levelVar}
+}
+
+func (l *logger) Sampled() Logger {
+	return &logger{zl: l.zl.Sample(zerolog.Sometimes), scope: l.scope, levelVar: l.levelVar}
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -62,6 +63,139 @@ func TestParseLevel(t *testing.T) {
 	}
 }
 
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Format
+	}{
+		{"json", FormatJSON},
+		{"JSON", FormatJSON},
+		{"logfmt", FormatLogfmt},
+		{"Logfmt", FormatLogfmt},
+		{"console", FormatConsole},
+		{"unknown", FormatConsole},
+		{"", FormatConsole},
+	}
+	for _, tt := range tests {
+		got := ParseFormat(tt.input)
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWithFormatJSON(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithSink(&buf, FormatJSON))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	l.Info("json test")
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON output, got %q: %v", buf.String(), err)
+	}
+	if decoded["message"] != "json test" {
+		t.Errorf("expected message field %q, got %v", "json test", decoded["message"])
+	}
+}
+
+func TestWithFormatLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithSink(&buf, FormatLogfmt))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	l.Info("logfmt test")
+
+	out := buf.String()
+	if !strings.Contains(out, "message=\"logfmt test\"") {
+		t.Errorf("expected logfmt output to contain quoted message, got %q", out)
+	}
+}
+
+func TestWithSinkMultiple(t *testing.T) {
+	var console, jsonBuf bytes.Buffer
+	l, err := New(LevelInfo,
+		WithSink(&console, FormatConsole),
+		WithSink(&jsonBuf, FormatJSON),
+	)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	l.Info("dual sink")
+
+	if !strings.Contains(console.String(), "dual sink") {
+		t.Errorf("expected console sink to contain message, got %q", console.String())
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid JSON output, got %q: %v", jsonBuf.String(), err)
+	}
+}
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	child := l.With("request_id", "abc123")
+	child.Info("handled request")
+
+	out := buf.String()
+	for _, want := range []string{"request_id", "abc123", "handled request"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got %q", want, out)
+		}
+	}
+}
+
+func TestScopeNesting(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithSink(&buf, FormatJSON))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	l.Scope("auth").Scope("login").Info("attempt")
+
+	// Nested scopes accumulate into a single dotted "scope" field instead
+	// of one replacing another, and the field appears exactly once.
+	out := buf.String()
+	if n := strings.Count(out, `"scope":`); n != 1 {
+		t.Errorf("expected exactly 1 scope field, got %d in %q", n, out)
+	}
+	if !strings.Contains(out, `"scope":"main.auth.login"`) {
+		t.Errorf("expected accumulated scope field %q, got %q", "main.auth.login", out)
+	}
+	if !strings.Contains(out, "attempt") {
+		t.Errorf("expected output to contain %q, got %q", "attempt", out)
+	}
+}
+
+func TestLevelVar(t *testing.T) {
+	var buf bytes.Buffer
+	lv := NewLevelVar(LevelInfo)
+	l, err := New(LevelInfo, WithWriter(&buf), WithLevelVar(lv))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	l.Debug("should-not-appear")
+	if strings.Contains(buf.String(), "should-not-appear") {
+		t.Errorf("expected debug to be filtered at info level, got %q", buf.String())
+	}
+
+	lv.Set(LevelDebug)
+	l.Debug("should-appear-now")
+	if !strings.Contains(buf.String(), "should-appear-now") {
+		t.Errorf("expected debug to appear after lowering LevelVar, got %q", buf.String())
+	}
+}
+
 func TestScope(t *testing.T) {
 	var buf bytes.Buffer
 	l, err := New(LevelInfo, WithWriter(&buf))
@@ -82,19 +216,54 @@ func TestAllMethods(t *testing.T) {
 		t.Fatalf("New() error: %v", err)
 	}
 
-	l.Info("info msg")
+	l.Info("info msg", "key", "value")
 	l.Event("event msg")
-	l.Debug("key", "value")
+	l.Debug("debug msg")
 	l.Error(errors.New("test error"), "error msg")
 
 	out := buf.String()
-	for _, want := range []string{"info msg", "event msg", "key", "value", "error msg"} {
+	for _, want := range []string{"info msg", "event msg", "key", "value", "debug msg", "error msg"} {
 		if !strings.Contains(out, want) {
 			t.Errorf("expected output to contain %q, got %q", want, out)
 		}
 	}
 }
 
+func TestStructuredKeyvals(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	l.Info("server started", "port", 8080, "tls", true)
+
+	out := buf.String()
+	for _, want := range []string{"port", "8080", "tls", "true"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got %q", want, out)
+		}
+	}
+}
+
+func TestBadKeyMarker(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	l.Info("odd keyvals", "key", "value", "orphan")
+
+	out := buf.String()
+	if !strings.Contains(out, badKeyMarker) {
+		t.Errorf("expected output to contain %q, got %q", badKeyMarker, out)
+	}
+	if !strings.Contains(out, "orphan") {
+		t.Errorf("expected output to contain the orphaned value %q, got %q", "orphan", out)
+	}
+}
+
 func TestLevelFiltering(t *testing.T) {
 	var buf bytes.Buffer
 	l, err := New(LevelInfo, WithWriter(&buf))
@@ -138,4 +307,5 @@ func TestNewNoop(t *testing.T) {
 	l.Debug("k", "v")
 	l.Error(errors.New("err"), "test")
 	l.Scope("sub").Info("test")
+	l.With("key", "value").Info("test")
 }
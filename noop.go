@@ -9,8 +9,10 @@ func NewNoop() Logger {
 	return &NoopLogger{}
 }
 
-func (n *NoopLogger) Info(string)          {}
-func (n *NoopLogger) Event(string)         {}
-func (n *NoopLogger) Debug(string, string) {}
-func (n *NoopLogger) Error(error, string)  {}
-func (n *NoopLogger) Scope(string) Logger  { return n }
+func (n *NoopLogger) Info(string, ...interface{})         {}
+func (n *NoopLogger) Event(string, ...interface{})        {}
+func (n *NoopLogger) Debug(string, ...interface{})        {}
+func (n *NoopLogger) Error(error, string, ...interface{}) {}
+func (n *NoopLogger) Scope(string) Logger                 { return n }
+func (n *NoopLogger) With(...interface{}) Logger          { return n }
+func (n *NoopLogger) Sampled() Logger                     { return n }
@@ -0,0 +1,266 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateOptions configures the rotation behavior of a file sink created
+// with WithRotatingFile, in the style of lumberjack.Logger.
+type RotateOptions struct {
+	// MaxSizeMB is the maximum size, in megabytes, a log file is allowed
+	// to reach before it's rotated. Zero disables size-based rotation.
+	MaxSizeMB int
+	// MaxBackups is the maximum number of rotated backups to retain.
+	// Zero keeps all of them.
+	MaxBackups int
+	// MaxAgeDays is the maximum age, in days, a backup is retained before
+	// being pruned. Zero disables age-based pruning.
+	MaxAgeDays int
+	// Compress gzip-compresses rotated backups.
+	Compress bool
+	// LocalTime uses the local time instead of UTC to timestamp backups.
+	LocalTime bool
+}
+
+// WithRotatingFile enables file logging at path, rotating it once
+// opts.MaxSizeMB is exceeded. Parent directories are created automatically
+// if they don't exist. The returned sink writes JSON, matching WithFile.
+func WithRotatingFile(path string, opts RotateOptions) Option {
+	return func(o *options) {
+		rw, err := newRotatingWriter(path, opts)
+		if err != nil {
+			o.err = err
+			return
+		}
+		o.sinks = append(o.sinks, sink{w: rw, format: FormatJSON})
+	}
+}
+
+// rotatingWriter is an io.WriteCloser that rotates its underlying file once
+// it exceeds opts.MaxSizeMB, renaming it to name-<timestamp>.log(.gz) and
+// pruning old backups per opts. It is safe for concurrent use.
+type rotatingWriter struct {
+	path string
+	opts RotateOptions
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func newRotatingWriter(path string, opts RotateOptions) (*rotatingWriter, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("logger: create log directory: %w", err)
+	}
+
+	rw := &rotatingWriter{path: path, opts: opts}
+	if err := rw.openCurrent(); err != nil {
+		return nil, err
+	}
+	return rw, nil
+}
+
+func (rw *rotatingWriter) openCurrent() error {
+	f, err := os.OpenFile(rw.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("logger: open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("logger: stat log file: %w", err)
+	}
+	rw.f = f
+	rw.size = info.Size()
+	return nil
+}
+
+func (rw *rotatingWriter) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.opts.MaxSizeMB > 0 && rw.size > 0 && rw.size+int64(len(p)) > int64(rw.opts.MaxSizeMB)*1024*1024 {
+		if err := rw.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rw.f.Write(p)
+	rw.size += int64(n)
+	return n, err
+}
+
+func (rw *rotatingWriter) Close() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	return rw.f.Close()
+}
+
+func (rw *rotatingWriter) rotate() error {
+	ts := time.Now()
+	if !rw.opts.LocalTime {
+		ts = ts.UTC()
+	}
+	return rw.rotateAt(ts)
+}
+
+// rotateAt does the work of rotate for a caller-supplied timestamp, so
+// tests can exercise backup-name collisions deterministically.
+func (rw *rotatingWriter) rotateAt(ts time.Time) error {
+	if err := rw.f.Close(); err != nil {
+		return fmt.Errorf("logger: close log file for rotation: %w", err)
+	}
+
+	backupPath := rw.uniqueBackupName(ts)
+	if err := os.Rename(rw.path, backupPath); err != nil {
+		return fmt.Errorf("logger: rotate log file: %w", err)
+	}
+
+	if rw.opts.Compress {
+		if err := compressFile(backupPath); err != nil {
+			return fmt.Errorf("logger: compress rotated log: %w", err)
+		}
+	}
+
+	rw.prune()
+
+	return rw.openCurrent()
+}
+
+// backupName returns the path a backup taken at t and disambiguated by seq
+// should be renamed to: name-<timestamp>.ext, or name-<timestamp>-<seq>.ext
+// when seq is positive.
+func (rw *rotatingWriter) backupName(t time.Time, seq int) string {
+	dir := filepath.Dir(rw.path)
+	base := filepath.Base(rw.path)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext)
+
+	name := fmt.Sprintf("%s-%s", prefix, t.Format("2006-01-02T15-04-05.000"))
+	if seq > 0 {
+		name = fmt.Sprintf("%s-%d", name, seq)
+	}
+	return filepath.Join(dir, name+ext)
+}
+
+// uniqueBackupName returns backupName(t, 0), or the first backupName(t, seq)
+// that doesn't already exist (as a plain or compressed file) if two
+// rotations land on the same millisecond-precision timestamp.
+func (rw *rotatingWriter) uniqueBackupName(t time.Time) string {
+	for seq := 0; ; seq++ {
+		path := rw.backupName(t, seq)
+		if !fileExists(path) && !fileExists(path+".gz") {
+			return path
+		}
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// compressFile gzips path to path+".gz" and removes path.
+func compressFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	gzFile, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer gzFile.Close()
+
+	gw := gzip.NewWriter(gzFile)
+	if _, err := gw.Write(data); err != nil {
+		gw.Close()
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+type backupFile struct {
+	path    string
+	modTime time.Time
+}
+
+// prune removes backups older than opts.MaxAgeDays and, if there are more
+// than opts.MaxBackups remaining, the oldest of those too.
+func (rw *rotatingWriter) prune() {
+	if rw.opts.MaxAgeDays <= 0 && rw.opts.MaxBackups <= 0 {
+		return
+	}
+
+	backups, err := rw.listBackups()
+	if err != nil {
+		return
+	}
+
+	var toRemove []string
+	if rw.opts.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -rw.opts.MaxAgeDays)
+		kept := backups[:0]
+		for _, b := range backups {
+			if b.modTime.Before(cutoff) {
+				toRemove = append(toRemove, b.path)
+			} else {
+				kept = append(kept, b)
+			}
+		}
+		backups = kept
+	}
+
+	if rw.opts.MaxBackups > 0 && len(backups) > rw.opts.MaxBackups {
+		sort.Slice(backups, func(i, j int) bool { return backups[i].modTime.After(backups[j].modTime) })
+		for _, b := range backups[rw.opts.MaxBackups:] {
+			toRemove = append(toRemove, b.path)
+		}
+	}
+
+	for _, path := range toRemove {
+		os.Remove(path)
+	}
+}
+
+// listBackups returns the rotated backups (compressed or not) for rw.path.
+func (rw *rotatingWriter) listBackups() ([]backupFile, error) {
+	dir := filepath.Dir(rw.path)
+	base := filepath.Base(rw.path)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []backupFile
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if !strings.HasSuffix(e.Name(), ext) && !strings.HasSuffix(e.Name(), ext+".gz") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, e.Name()), modTime: info.ModTime()})
+	}
+	return backups, nil
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SampleOptions configures WithSampling. Set exactly one of Sampler, the
+// Burst/Period pair, or N; they're checked in that order of precedence.
+type SampleOptions struct {
+	// Sampler, if set, is used as-is, letting callers supply any
+	// zerolog.Sampler (e.g. a zerolog.LevelSampler to sample Debug
+	// aggressively while leaving Error unsampled).
+	Sampler zerolog.Sampler
+	// Burst is the number of events allowed through per Period before
+	// AfterBurst (or rejection, if AfterBurst is nil) takes over, e.g.
+	// "first 5 per second, then 1 per 100" is Burst: 5, Period: time.Second,
+	// AfterBurst: &zerolog.BasicSampler{N: 100}.
+	Burst      uint32
+	Period     time.Duration
+	AfterBurst zerolog.Sampler
+	// N samples every Nth event via zerolog's BasicSampler.
+	N uint32
+}
+
+// sampler resolves opts to the zerolog.Sampler it describes, or nil if
+// opts is the zero value.
+func (opts SampleOptions) sampler() zerolog.Sampler {
+	switch {
+	case opts.Sampler != nil:
+		return opts.Sampler
+	case opts.Burst > 0:
+		return &zerolog.BurstSampler{Burst: opts.Burst, Period: opts.Period, NextSampler: opts.AfterBurst}
+	case opts.N > 0:
+		return &zerolog.BasicSampler{N: opts.N}
+	default:
+		return nil
+	}
+}
+
+// WithSampling applies opts' sampler to every call made through the
+// returned Logger, protecting hot paths (e.g. Debug in a tight loop) from
+// drowning the configured sinks. Use Logger.Sampled() instead to opt a
+// single call site into sampling without affecting the rest of the logger.
+func WithSampling(opts SampleOptions) Option {
+	return func(o *options) {
+		o.sampler = opts.sampler()
+	}
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestWithSamplingBasic(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithWriter(&buf), WithSampling(SampleOptions{N: 2}))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		l.Info("tick")
+	}
+
+	got := strings.Count(buf.String(), "tick")
+	if got != 5 {
+		t.Errorf("expected every-2nd sampling to log 5 of 10 events, got %d", got)
+	}
+}
+
+func TestWithSamplingCustomSampler(t *testing.T) {
+	var buf bytes.Buffer
+	sampler := &zerolog.BasicSampler{N: 3}
+	l, err := New(LevelInfo, WithWriter(&buf), WithSampling(SampleOptions{Sampler: sampler}))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	for i := 0; i < 9; i++ {
+		l.Info("tick")
+	}
+
+	got := strings.Count(buf.String(), "tick")
+	if got != 3 {
+		t.Errorf("expected every-3rd sampling to log 3 of 9 events, got %d", got)
+	}
+}
+
+func TestSampledShortcut(t *testing.T) {
+	var buf bytes.Buffer
+	l, err := New(LevelInfo, WithWriter(&buf))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		l.Sampled().Info("tick")
+	}
+
+	got := strings.Count(buf.String(), "tick")
+	if got == 0 || got == 1000 {
+		t.Errorf("expected Sampled() to drop some but not all events, got %d of 1000", got)
+	}
+}
+
+func TestSampledNoopIsNoop(t *testing.T) {
+	var l Logger = NewNoop()
+
+	// Should not panic.
+	l.Sampled().Info("test")
+}